@@ -0,0 +1,198 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// splitVersion extracts the MAJOR.MINOR.PATCH integers from the start of v,
+// ignoring any leading "v" and trailing pre-release/build suffix. v is
+// normalized via NormalizeVersion first, so callers may pass either a bare
+// tag without the leading "v" like "1.30.4" or a full gitVersion like
+// "v1.30.4+k3s1".
+func splitVersion(v string) (major, minor, patch int, err error) {
+	canonical, err := NormalizeVersion(v)
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("not a valid MAJOR.MINOR.PATCH version: %s", v)
+	}
+	core := strings.TrimPrefix(canonical, "v")
+	if i := strings.IndexByte(core, '-'); i >= 0 {
+		core = core[:i]
+	}
+	parts := strings.SplitN(core, ".", 3)
+	major, _ = strconv.Atoi(parts[0])
+	minor, _ = strconv.Atoi(parts[1])
+	patch, _ = strconv.Atoi(parts[2])
+	return major, minor, patch, nil
+}
+
+// kubectlVersionOutput is the subset of `kubectl version -o json` this tool
+// cares about.
+type kubectlVersionOutput struct {
+	ServerVersion struct {
+		GitVersion string `json:"gitVersion"`
+	} `json:"serverVersion"`
+}
+
+// getActiveClusterVersion shells out to kubectl to discover the active
+// cluster's kube-apiserver GitVersion (e.g. "v1.30.6" or "v1.30.4+k3s1").
+func getActiveClusterVersion() (string, error) {
+	out, err := exec.Command("kubectl", "version", "-o", "json").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to run kubectl version: %w", err)
+	}
+
+	var parsed kubectlVersionOutput
+	if err := json.Unmarshal(out, &parsed); err != nil {
+		return "", fmt.Errorf("failed to unmarshal kubectl version output: %w", err)
+	}
+	if parsed.ServerVersion.GitVersion == "" {
+		return "", fmt.Errorf("kubectl version output did not include a server gitVersion")
+	}
+	return parsed.ServerVersion.GitVersion, nil
+}
+
+// findProvider returns the registered Provider with the given Name, if any.
+func findProvider(name string) (Provider, error) {
+	for _, p := range providerRegistry {
+		if p.Name() == name {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown provider %q", name)
+}
+
+// resolveClusterVersion maps gitVersion onto the closest version present in
+// candidates, mirroring kube-bench's mapToBenchmarkVersion: look up the
+// exact MAJOR.MINOR.PATCH first; if missing, decrement the patch, and then
+// the minor, until a match is found, bailing once the minor would go below
+// zero. It returns the matched candidate string as given (so a suffix like
+// "+k3s1" is kept) and whether the match was exact.
+func resolveClusterVersion(gitVersion string, candidates []string) (resolved string, exact bool, err error) {
+	major, minor, patch, err := splitVersion(gitVersion)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to parse cluster version %q: %w", gitVersion, err)
+	}
+
+	type candidatePatch struct {
+		patch   int
+		version string
+	}
+	patchesByMinor := make(map[int][]candidatePatch)
+	for _, candidate := range candidates {
+		cMajor, cMinor, cPatch, err := splitVersion(candidate)
+		if err != nil || cMajor != major {
+			continue
+		}
+		patchesByMinor[cMinor] = append(patchesByMinor[cMinor], candidatePatch{patch: cPatch, version: candidate})
+	}
+	for m := range patchesByMinor {
+		patches := patchesByMinor[m]
+		sort.Slice(patches, func(i, j int) bool { return patches[i].patch > patches[j].patch })
+	}
+
+	for m := minor; m >= 0; m-- {
+		patches, ok := patchesByMinor[m]
+		if !ok {
+			continue
+		}
+		startPatch := patch
+		if m != minor {
+			// No candidate at or below the cluster's patch exists for its own
+			// minor; fall back to the newest known patch of the nearest lower
+			// minor that has candidates.
+			startPatch = patches[0].patch
+		}
+		for _, c := range patches {
+			if c.patch > startPatch {
+				continue
+			}
+			return c.version, m == minor && c.patch == patch, nil
+		}
+	}
+	return "", false, fmt.Errorf("no supported version found for cluster version %s", gitVersion)
+}
+
+// runFromClusterCommand implements the "from-cluster" subcommand: it
+// resolves the active cluster's kube-apiserver version to the closest
+// version a distribution's provider supports, and with -pin rewrites
+// test-matrix.json so that distribution's entry contains only the resolved
+// version.
+func runFromClusterCommand(args []string) {
+	fs := flag.NewFlagSet("from-cluster", flag.ExitOnError)
+	fs.BoolVar(&debug, "debug", false, "Enable debug logging")
+	providerName := fs.String("provider", "", `Distribution to resolve against, one of "kind", "minikube", or "k3s". Defaults to "k3s" when the cluster's gitVersion contains "+k3s", otherwise required.`)
+	pin := fs.Bool("pin", false, "Rewrite test-matrix.json so the resolved distribution's entry contains only the resolved version")
+	fs.Parse(args)
+
+	log.SetOutput(os.Stdout)
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	gitVersion, err := getActiveClusterVersion()
+	if err != nil {
+		log.Println("Failed to get active cluster version: ", err)
+		os.Exit(1)
+	}
+	logDebug("Active cluster reports version %s", gitVersion)
+
+	name := *providerName
+	if name == "" {
+		if strings.Contains(gitVersion, "+k3s") {
+			name = "k3s"
+		} else {
+			log.Println("Unable to infer the active cluster's distribution from its version; pass -provider")
+			os.Exit(1)
+		}
+	}
+	provider, err := findProvider(name)
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+
+	k8sVersions, err := getSupportedKubernetesVersions()
+	if err != nil || len(k8sVersions) == 0 {
+		log.Println("Failed to get k8s versions: ", err)
+		os.Exit(1)
+	}
+
+	candidates, err := provider.LatestImagesFor(k8sVersions)
+	if err != nil {
+		log.Println("Failed to discover supported versions: ", err)
+		os.Exit(1)
+	}
+
+	resolved, exact, err := resolveClusterVersion(gitVersion, candidates)
+	if err != nil {
+		log.Println("Failed to map cluster version to a supported version: ", err)
+		os.Exit(1)
+	}
+	if exact {
+		fmt.Printf("Active cluster version %s is an exact match for %s %s\n", gitVersion, provider.Name(), resolved)
+	} else {
+		log.Printf("Active cluster version %s has no exact match; approximating with %s %s", gitVersion, provider.Name(), resolved)
+	}
+
+	if *pin {
+		path, err := matrixFilePath()
+		if err != nil {
+			log.Println(err)
+			os.Exit(1)
+		}
+		if err := updateMatrixFile(path, map[string][]string{provider.MatrixKey(): {resolved}}); err != nil {
+			log.Println("Failed to pin matrix file: ", err)
+			os.Exit(1)
+		}
+	}
+	os.Exit(0)
+}
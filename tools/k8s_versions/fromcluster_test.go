@@ -0,0 +1,52 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "testing"
+
+func TestSplitVersion_ParsesCoreComponents(t *testing.T) {
+	major, minor, patch, err := splitVersion("v1.30.4+k3s1")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if major != 1 || minor != 30 || patch != 4 {
+		t.Fatalf("Expected 1.30.4, got %d.%d.%d", major, minor, patch)
+	}
+}
+
+func TestResolveClusterVersion_ExactMatch(t *testing.T) {
+	resolved, exact, err := resolveClusterVersion("v1.30.4", []string{"v1.30.2", "v1.30.4", "v1.29.9"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !exact || resolved != "v1.30.4" {
+		t.Fatalf("Expected an exact match on v1.30.4, got %s (exact=%v)", resolved, exact)
+	}
+}
+
+func TestResolveClusterVersion_DecrementsPatchWithinMinor(t *testing.T) {
+	resolved, exact, err := resolveClusterVersion("v1.30.6", []string{"v1.30.2", "v1.30.4"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if exact || resolved != "v1.30.4" {
+		t.Fatalf("Expected an approximate match on v1.30.4, got %s (exact=%v)", resolved, exact)
+	}
+}
+
+func TestResolveClusterVersion_FallsBackToOlderMinor(t *testing.T) {
+	resolved, exact, err := resolveClusterVersion("v1.30.6", []string{"v1.29.9", "v1.28.4"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if exact || resolved != "v1.29.9" {
+		t.Fatalf("Expected an approximate match on v1.29.9, got %s (exact=%v)", resolved, exact)
+	}
+}
+
+func TestResolveClusterVersion_NoCandidateBelow_ReturnsError(t *testing.T) {
+	if _, _, err := resolveClusterVersion("v1.30.0", []string{"v1.31.0"}); err == nil {
+		t.Fatal("Expected an error when no candidate is at or below the cluster version")
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+const (
+	defaultHTTPTimeout = 30 * time.Second
+	maxRetries         = 5
+	baseBackoff        = 500 * time.Millisecond
+	maxBackoff         = 30 * time.Second
+)
+
+// defaultHTTPClient is the httpClient every getRequest call goes through.
+var defaultHTTPClient = newHTTPClient()
+
+// httpClient wraps *http.Client with retry-with-backoff on 429/5xx responses
+// and an on-disk ETag/Last-Modified cache, to stay within Docker Hub's
+// aggressive anonymous rate limits across repeated runs.
+type httpClient struct {
+	client   *http.Client
+	cacheDir string
+}
+
+// cacheEntry is what's persisted on disk per cached URL.
+type cacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// newHTTPClient builds an httpClient caching responses under
+// $XDG_CACHE_HOME/k8s-versions/ (see os.UserCacheDir). Caching is disabled,
+// rather than failing, if no cache directory is available.
+func newHTTPClient() *httpClient {
+	cacheDir := ""
+	if base, err := os.UserCacheDir(); err == nil {
+		cacheDir = filepath.Join(base, "k8s-versions")
+	}
+	return &httpClient{
+		client:   &http.Client{Timeout: defaultHTTPTimeout},
+		cacheDir: cacheDir,
+	}
+}
+
+// Get fetches url, returning the cached body unmodified on a 304, and
+// retrying with exponential backoff and jitter on 429/5xx responses.
+func (c *httpClient) Get(url string) ([]byte, error) {
+	cached, _ := c.readCache(url)
+
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build request: %w", err)
+		}
+		if cached != nil {
+			if cached.ETag != "" {
+				req.Header.Set("If-None-Match", cached.ETag)
+			}
+			if cached.LastModified != "" {
+				req.Header.Set("If-Modified-Since", cached.LastModified)
+			}
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			lastErr = fmt.Errorf("failed to fetch URL: %w", err)
+			c.sleepBackoff(attempt, nil)
+			continue
+		}
+
+		if resp.StatusCode == http.StatusNotModified {
+			resp.Body.Close()
+			if cached == nil {
+				lastErr = fmt.Errorf("received 304 with no cached body for %s", url)
+				continue
+			}
+			return cached.Body, nil
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			lastErr = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+			c.sleepBackoff(attempt, resp)
+			resp.Body.Close()
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response body: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		}
+
+		entry := cacheEntry{
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Body:         body,
+		}
+		if entry.ETag != "" || entry.LastModified != "" {
+			c.writeCache(url, entry)
+		}
+		return body, nil
+	}
+	return nil, fmt.Errorf("giving up after %d retries: %w", maxRetries, lastErr)
+}
+
+// GetAllPages fetches url, then repeatedly calls nextURL with the response
+// body just fetched to determine the next page, stopping once nextURL
+// returns an empty string. It returns every page body encountered.
+func (c *httpClient) GetAllPages(url string, nextURL func(body []byte) (string, error)) ([][]byte, error) {
+	var bodies [][]byte
+	for url != "" {
+		body, err := c.Get(url)
+		if err != nil {
+			return bodies, err
+		}
+		bodies = append(bodies, body)
+
+		next, err := nextURL(body)
+		if err != nil {
+			return bodies, err
+		}
+		url = next
+	}
+	return bodies, nil
+}
+
+// sleepBackoff waits before the next retry attempt using exponential
+// backoff with jitter, honoring a response's Retry-After or RateLimit-Reset
+// header when present.
+func (c *httpClient) sleepBackoff(attempt int, resp *http.Response) {
+	if resp != nil {
+		if wait, ok := retryAfter(resp); ok {
+			time.Sleep(wait)
+			return
+		}
+	}
+	backoff := baseBackoff * time.Duration(int64(1)<<uint(attempt))
+	if backoff > maxBackoff {
+		backoff = maxBackoff
+	}
+	jitter := time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+	time.Sleep(backoff/2 + jitter)
+}
+
+// retryAfter extracts a wait duration from a response's Retry-After or
+// RateLimit-Reset header, if either is present and parseable.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if v := resp.Header.Get("RateLimit-Reset"); v != "" {
+		if unixTime, err := strconv.ParseInt(v, 10, 64); err == nil {
+			if wait := time.Until(time.Unix(unixTime, 0)); wait > 0 {
+				return wait, true
+			}
+		}
+	}
+	return 0, false
+}
+
+func (c *httpClient) cachePath(url string) (string, bool) {
+	if c.cacheDir == "" {
+		return "", false
+	}
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(c.cacheDir, hex.EncodeToString(sum[:])+".json"), true
+}
+
+func (c *httpClient) readCache(url string) (*cacheEntry, error) {
+	path, ok := c.cachePath(url)
+	if !ok {
+		return nil, nil
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(content, &entry); err != nil {
+		return nil, nil
+	}
+	return &entry, nil
+}
+
+func (c *httpClient) writeCache(url string, entry cacheEntry) {
+	path, ok := c.cachePath(url)
+	if !ok {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	content, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, content, 0644)
+}
+
+// getRequest fetches url through the shared, cache- and retry-aware
+// defaultHTTPClient.
+func getRequest(url string) ([]byte, error) {
+	return defaultHTTPClient.Get(url)
+}
@@ -0,0 +1,133 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestHTTPClient(t *testing.T) *httpClient {
+	t.Helper()
+	return &httpClient{
+		client:   &http.Client{Timeout: 2 * time.Second},
+		cacheDir: t.TempDir(),
+	}
+}
+
+func TestHTTPClient_Get_ReusesCacheOn304(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	c := newTestHTTPClient(t)
+
+	first, err := c.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	second, err := c.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("Expected 2 requests to the server, got %d", requests)
+	}
+	if string(first) != string(second) {
+		t.Fatalf("Expected the cached body to match the original body, got %q vs %q", first, second)
+	}
+}
+
+func TestHTTPClient_Get_RetriesOn429ThenSucceeds(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 2 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	c := newTestHTTPClient(t)
+
+	body, err := c.Get(server.URL)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if requests != 2 {
+		t.Fatalf("Expected exactly one retry (2 requests), got %d", requests)
+	}
+	if string(body) != `{"ok":true}` {
+		t.Fatalf("Expected the successful body, got %q", body)
+	}
+}
+
+func TestHTTPClient_Get_HonorsRetryAfterHeader(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	c := newTestHTTPClient(t)
+
+	start := time.Now()
+	if _, err := c.Get(server.URL); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// The default backoff before the first retry waits at least baseBackoff/2.
+	if elapsed := time.Since(start); elapsed >= baseBackoff/2 {
+		t.Fatalf("Expected Retry-After: 0 to avoid the default backoff, took %s", elapsed)
+	}
+}
+
+func TestHTTPClient_GetAllPages_StopsWhenNextIsEmpty(t *testing.T) {
+	pages := [][]byte{[]byte(`[1,2]`), []byte(`[3]`), []byte(`[]`)}
+	requested := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(pages[requested])
+		requested++
+	}))
+	defer server.Close()
+
+	c := newTestHTTPClient(t)
+
+	nextPage := 0
+	bodies, err := c.GetAllPages(server.URL, func(body []byte) (string, error) {
+		if string(body) == "[]" {
+			return "", nil
+		}
+		nextPage++
+		return server.URL, nil
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("Expected 3 pages, got %d", len(bodies))
+	}
+}
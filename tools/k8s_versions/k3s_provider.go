@@ -0,0 +1,118 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// K3sReleasesURL is the GitHub Releases API endpoint for k3s-io/k3s,
+// paginated via the "page" query parameter.
+const K3sReleasesURL string = "https://api.github.com/repos/k3s-io/k3s/releases?per_page=100"
+
+func init() {
+	RegisterProvider(&k3sProvider{})
+}
+
+// k3sTagPattern matches k3s release tags, e.g. "v1.30.4+k3s1".
+var k3sTagPattern = regexp.MustCompile(`^v(\d+)\.(\d+)\.(\d+)(?:\+k3s(\d+))?$`)
+
+type k3sRelease struct {
+	TagName    string `json:"tag_name"`
+	Prerelease bool   `json:"prerelease"`
+}
+
+// k3sProvider discovers the newest non-prerelease k3s/k3d release tag, per
+// supported Kubernetes cycle, from the k3s-io/k3s GitHub Releases API.
+type k3sProvider struct{}
+
+func (p *k3sProvider) Name() string      { return "k3s" }
+func (p *k3sProvider) MatrixKey() string { return "k8s-k3s-version" }
+
+func (p *k3sProvider) LatestImagesFor(k8sVersions []KubernetesVersion) ([]string, error) {
+	releases, err := fetchK3sReleases()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch k3s releases: %w", err)
+	}
+
+	var latestK3sVersions []string
+	for _, k8sVersion := range k8sVersions {
+		best, bestPatch, bestBuild := "", -1, -1
+		for _, release := range releases {
+			if release.Prerelease {
+				continue
+			}
+			major, minor, patch, build, ok := parseK3sTag(release.TagName)
+			if !ok || fmt.Sprintf("%s.%s", major, minor) != k8sVersion.Cycle {
+				continue
+			}
+			if patch > bestPatch || (patch == bestPatch && build > bestBuild) {
+				best, bestPatch, bestBuild = release.TagName, patch, build
+			}
+		}
+		if best != "" {
+			latestK3sVersions = append(latestK3sVersions, best)
+		} else {
+			logDebug("No k3s release found for k8s version %s", k8sVersion.Cycle)
+		}
+	}
+	return latestK3sVersions, nil
+}
+
+// parseK3sTag splits a k3s release tag like "v1.30.4+k3s1" into its
+// major, minor, patch and k3s build components. build is -1 when the tag
+// has no "+k3sN" suffix.
+func parseK3sTag(tag string) (major, minor string, patch, build int, ok bool) {
+	matches := k3sTagPattern.FindStringSubmatch(tag)
+	if matches == nil {
+		return "", "", 0, 0, false
+	}
+	patch, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return "", "", 0, 0, false
+	}
+	build = -1
+	if matches[4] != "" {
+		build, err = strconv.Atoi(matches[4])
+		if err != nil {
+			return "", "", 0, 0, false
+		}
+	}
+	return matches[1], matches[2], patch, build, true
+}
+
+// fetchK3sReleases pages through the k3s-io/k3s GitHub Releases API until a
+// page comes back empty, returning every release encountered.
+func fetchK3sReleases() ([]k3sRelease, error) {
+	page := 1
+	nextPage := func(body []byte) (string, error) {
+		var releases []k3sRelease
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return "", fmt.Errorf("failed to unmarshal JSON: %w", err)
+		}
+		if len(releases) == 0 {
+			return "", nil
+		}
+		page++
+		return fmt.Sprintf("%s&page=%d", K3sReleasesURL, page), nil
+	}
+
+	bodies, err := defaultHTTPClient.GetAllPages(fmt.Sprintf("%s&page=%d", K3sReleasesURL, page), nextPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get k3s releases: %w", err)
+	}
+
+	var allReleases []k3sRelease
+	for _, body := range bodies {
+		var releases []k3sRelease
+		if err := json.Unmarshal(body, &releases); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+		}
+		allReleases = append(allReleases, releases...)
+	}
+	return allReleases, nil
+}
@@ -0,0 +1,32 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "testing"
+
+func TestParseK3sTag_ValidTag_ReturnsComponents(t *testing.T) {
+	major, minor, patch, build, ok := parseK3sTag("v1.30.4+k3s1")
+	if !ok {
+		t.Fatal("Expected ok, got false")
+	}
+	if major != "1" || minor != "30" || patch != 4 || build != 1 {
+		t.Fatalf("Expected 1.30.4+k3s1, got %s.%s.%d+k3s%d", major, minor, patch, build)
+	}
+}
+
+func TestParseK3sTag_NoBuildSuffix_ReturnsNegativeBuild(t *testing.T) {
+	_, _, _, build, ok := parseK3sTag("v1.30.4")
+	if !ok {
+		t.Fatal("Expected ok, got false")
+	}
+	if build != -1 {
+		t.Fatalf("Expected build -1, got %d", build)
+	}
+}
+
+func TestParseK3sTag_InvalidTag_ReturnsNotOK(t *testing.T) {
+	if _, _, _, _, ok := parseK3sTag("not-a-tag"); ok {
+		t.Fatal("Expected ok to be false for an invalid tag")
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DockerHubTagsURL is the kindest/node tags endpoint on Docker Hub, paginated
+// via the "next" field in each response.
+const DockerHubTagsURL string = "https://hub.docker.com/v2/repositories/kindest/node/tags?page_size=100&name="
+
+func init() {
+	RegisterProvider(&kindProvider{})
+	RegisterProvider(&minikubeProvider{})
+}
+
+// kindProvider discovers the latest kindest/node image tag, per supported
+// Kubernetes cycle, that exists on Docker Hub.
+type kindProvider struct{}
+
+func (p *kindProvider) Name() string      { return "kind" }
+func (p *kindProvider) MatrixKey() string { return "k8s-kind-version" }
+
+// LatestImagesFor iterates through the K8s supported versions and, for each,
+// lists the Docker Hub tags matching that cycle once and picks the newest
+// via LatestForCycle, rather than probing tags one at a time.
+func (p *kindProvider) LatestImagesFor(k8sVersions []KubernetesVersion) ([]string, error) {
+	var supportedKindVersions []string
+	for _, k8sVersion := range k8sVersions {
+		tags, err := fetchDockerHubTags(k8sVersion.Cycle)
+		if err != nil {
+			return supportedKindVersions, fmt.Errorf("failed to list kind image tags: %w", err)
+		}
+		best := LatestForCycle(tags, k8sVersion.Cycle)
+		if best == "" {
+			logDebug("No kind image found for k8s version %s", k8sVersion.Cycle)
+			continue
+		}
+		supportedKindVersions = append(supportedKindVersions, best)
+	}
+	return supportedKindVersions, nil
+}
+
+type dockerHubTagsResponse struct {
+	Next    string `json:"next"`
+	Results []struct {
+		Name string `json:"name"`
+	} `json:"results"`
+}
+
+// fetchDockerHubTags pages through the kindest/node tags matching nameFilter,
+// following the "next" link until it is empty, and returns every tag name.
+func fetchDockerHubTags(nameFilter string) ([]string, error) {
+	bodies, err := defaultHTTPClient.GetAllPages(DockerHubTagsURL+nameFilter, dockerHubNextPage)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get docker hub tags: %w", err)
+	}
+
+	var tags []string
+	for _, body := range bodies {
+		var resp dockerHubTagsResponse
+		if err := json.Unmarshal(body, &resp); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+		}
+		for _, result := range resp.Results {
+			tags = append(tags, result.Name)
+		}
+	}
+	return tags, nil
+}
+
+func dockerHubNextPage(body []byte) (string, error) {
+	var resp dockerHubTagsResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+	return resp.Next, nil
+}
+
+// minikubeProvider discovers the latest minikube-supported Kubernetes
+// version, per supported cycle, from minikube's own version constants.
+type minikubeProvider struct{}
+
+func (p *minikubeProvider) Name() string      { return "minikube" }
+func (p *minikubeProvider) MatrixKey() string { return "k8s-minikube-version" }
+
+func (p *minikubeProvider) LatestImagesFor(k8sVersions []KubernetesVersion) ([]string, error) {
+	body, err := getRequest(MiniKubeURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get minikube versions: %w", err)
+	}
+
+	// Extract the slice using a regular expression
+	re := regexp.MustCompile(`ValidKubernetesVersions = \[\]string{([^}]*)}`)
+	matches := re.FindStringSubmatch(string(body))
+	if len(matches) < 2 {
+		return nil, fmt.Errorf("minikube, failed to find the Kubernetes versions slice")
+	}
+
+	// Parse and cleanup the slice values
+	versions := strings.Split(strings.ReplaceAll(strings.ReplaceAll(matches[1], "\n", ""), `"`, ""), ",")
+
+	logDebug("Found the following minikube versions: %s", versions)
+
+	var latestMinikubeVersions []string
+	// the minikube version slice is sorted, break when first cycle match is found
+	for _, k8sVersion := range k8sVersions {
+		for _, version := range versions {
+			if strings.Contains(version, k8sVersion.Cycle) {
+				latestMinikubeVersions = append(latestMinikubeVersions, strings.TrimSpace(version))
+				break
+			}
+		}
+	}
+
+	return latestMinikubeVersions, nil
+}
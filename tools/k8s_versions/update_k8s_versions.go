@@ -8,15 +8,9 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"log"
-	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
-	"sort"
-	"strconv"
-	"strings"
 	"time"
 )
 
@@ -24,7 +18,6 @@ var debug bool
 
 const (
 	EndOfLifeURL string = "https://endoflife.date/api/kubernetes.json"
-	DockerHubURL string = "https://hub.docker.com/v2/repositories/kindest/node/tags?page_size=1&page=1&ordering=last_updated&name="
 	MiniKubeURL  string = "https://raw.githubusercontent.com/kubernetes/minikube/master/pkg/minikube/constants/constants_kubernetes_versions.go"
 )
 
@@ -35,22 +28,53 @@ type KubernetesVersion struct {
 	Latest      string `json:"latest"`
 }
 
-type DockerImage struct {
-	Count int `json:"count"`
+// Provider discovers the latest image/version a Kubernetes distribution
+// supports for each requested cycle and knows which test-matrix.json key it
+// owns. Adding a new distribution means adding a new file that implements
+// Provider and registers itself via RegisterProvider in an init func, rather
+// than adding another hardcoded branch here.
+type Provider interface {
+	// Name is a short human-readable name used in logging.
+	Name() string
+	// MatrixKey is the test-matrix.json key this provider updates.
+	MatrixKey() string
+	// LatestImagesFor returns, for each k8sVersion, the latest version string
+	// this distribution supports. Cycles with no match are omitted.
+	LatestImagesFor(k8sVersions []KubernetesVersion) ([]string, error)
 }
 
-// getSupportedKubernetesVersions returns only the supported Kubernetes versions
-// by checking the EOL date of the collected versions.
-func getSupportedKubernetesVersions() ([]KubernetesVersion, error) {
+// providerRegistry holds every Provider registered via RegisterProvider.
+var providerRegistry []Provider
+
+// RegisterProvider adds a Provider to the set consulted by main. Call it
+// from an init func in the file that implements the new provider.
+func RegisterProvider(p Provider) {
+	providerRegistry = append(providerRegistry, p)
+}
+
+// getKubernetesVersions fetches every Kubernetes release cycle known to
+// endoflife.date, including ones that are already past their EOL date.
+func getKubernetesVersions() ([]KubernetesVersion, error) {
 	body, err := getRequest(EndOfLifeURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get k8s versions: %w", err)
 	}
-	var kubernetesVersions, supportedKubernetesVersions []KubernetesVersion
+	var kubernetesVersions []KubernetesVersion
 	if err := json.Unmarshal(body, &kubernetesVersions); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
+	return kubernetesVersions, nil
+}
+
+// getSupportedKubernetesVersions returns only the supported Kubernetes versions
+// by checking the EOL date of the collected versions.
+func getSupportedKubernetesVersions() ([]KubernetesVersion, error) {
+	kubernetesVersions, err := getKubernetesVersions()
+	if err != nil {
+		return nil, err
+	}
 
+	var supportedKubernetesVersions []KubernetesVersion
 	now := time.Now()
 	for _, kubernetesVersion := range kubernetesVersions {
 		eolDate, err := time.Parse("2006-01-02", kubernetesVersion.EOLDate)
@@ -66,105 +90,22 @@ func getSupportedKubernetesVersions() ([]KubernetesVersion, error) {
 	return supportedKubernetesVersions, nil
 }
 
-func getLatestSupportedMinikubeVersions(k8sVersions []KubernetesVersion) ([]string, error) {
-	body, err := getRequest(MiniKubeURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get minikube versions: %w", err)
-	}
-
-	// Extract the slice using a regular expression
-	re := regexp.MustCompile(`ValidKubernetesVersions = \[\]string{([^}]*)}`)
-	matches := re.FindStringSubmatch(string(body))
-	if len(matches) < 2 {
-		return nil, fmt.Errorf("minikube, failed to find the Kubernetes versions slice")
-	}
-
-	// Parse and cleanup the slice values
-	versions := strings.Split(strings.ReplaceAll(strings.ReplaceAll(matches[1], "\n", ""), `"`, ""), ",")
-
-	logDebug("Found the following minikube versions: %s", versions)
-
-	var latestMinikubeVersions []string
-	// the minikube version slice is sorted, break when first cycle match is found
-	for _, k8sVersion := range k8sVersions {
-		for _, version := range versions {
-			if strings.Contains(version, k8sVersion.Cycle) {
-				latestMinikubeVersions = append(latestMinikubeVersions, strings.TrimSpace(version))
-				break
-			}
-		}
-	}
-
-	return latestMinikubeVersions, nil
-}
-
-// getLatestSupportedKindImages iterates through the K8s supported versions and find the latest kind
-// tag that supports that version
-func getLatestSupportedKindImages(k8sVersions []KubernetesVersion) ([]string, error) {
-	var supportedKindVersions []string
-	for _, k8sVersion := range k8sVersions {
-		tag := k8sVersion.Latest
-		for {
-			exists, err := imageTagExists(tag)
-			if err != nil {
-				return supportedKindVersions, fmt.Errorf("failed to check image tag existence: %w", err)
-			}
-			if exists {
-				supportedKindVersions = append(supportedKindVersions, "v"+tag)
-				break
-			}
-			tag, err = decrementMinorMinorVersion(tag)
-			if err != nil {
-				// It's possible that kind still does not have a tag for new versions, break the loop and
-				// process other k8s versions
-				if strings.Contains(err.Error(), "minor version cannot be decremented below 0") {
-					logDebug("No kind image found for k8s version %s", k8sVersion.Cycle)
-					break
-				}
-				return supportedKindVersions, fmt.Errorf("failed to decrement k8sVersion: %w", err)
-			}
-		}
-	}
-	return supportedKindVersions, nil
-}
-
-func imageTagExists(tag string) (bool, error) {
-	body, err := getRequest(DockerHubURL + tag)
-	if err != nil {
-		return false, fmt.Errorf("failed to get image tag: %w", err)
-	}
-
-	var kindImage DockerImage
-	if err := json.Unmarshal(body, &kindImage); err != nil {
-		return false, fmt.Errorf("failed to unmarshal JSON: %w", err)
-	}
-
-	if kindImage.Count > 0 {
-		return true, nil
-	}
-	return false, nil
-}
-
-func decrementMinorMinorVersion(version string) (string, error) {
-	parts := strings.Split(version, ".")
-	if len(parts) < 3 {
-		return "", fmt.Errorf("version does not have a minor version: %s", version)
-	}
-
-	minor, err := strconv.Atoi(parts[2])
-	if err != nil {
-		return "", fmt.Errorf("invalid minor version: %s", parts[1])
-	}
-
-	if minor == 0 {
-		return "", fmt.Errorf("minor version cannot be decremented below 0")
+// eolDatesByCycle maps each known cycle to its EOL date, including cycles
+// that are no longer supported, so callers can annotate a removed cycle with
+// when it went EOL.
+func eolDatesByCycle(kubernetesVersions []KubernetesVersion) map[string]string {
+	eolDates := make(map[string]string, len(kubernetesVersions))
+	for _, kubernetesVersion := range kubernetesVersions {
+		eolDates[majorMinor(kubernetesVersion.Cycle)] = kubernetesVersion.EOLDate
 	}
-
-	parts[2] = strconv.Itoa(minor - 1)
-	return strings.Join(parts, "."), nil
+	return eolDates
 }
 
-func updateMatrixFile(filePath string, kindVersions []string, minikubeVersions []string) error {
+// updateMatrixFile rewrites filePath's test-matrix.json entries, replacing
+// the value for each provider's MatrixKey with the versions it discovered.
+// Providers with no discovered versions, or keys a given matrix entry does
+// not declare, are left untouched.
+func updateMatrixFile(filePath string, providerVersions map[string][]string) error {
 	content, err := os.ReadFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read file: %w", err)
@@ -175,11 +116,11 @@ func updateMatrixFile(filePath string, kindVersions []string, minikubeVersions [
 		return fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
-	for _, value := range testMatrix {
-		if len(kindVersions) > 0 && value["k8s-kind-version"] != nil {
-			value["k8s-kind-version"] = kindVersions
-		} else if len(minikubeVersions) > 0 && value["k8s-minikube-version"] != nil {
-			value["k8s-minikube-version"] = minikubeVersions
+	for _, entry := range testMatrix {
+		for matrixKey, versions := range providerVersions {
+			if len(versions) > 0 && entry[matrixKey] != nil {
+				entry[matrixKey] = versions
+			}
 		}
 	}
 	// Marshal the updated test matrix back to JSON
@@ -194,48 +135,41 @@ func updateMatrixFile(filePath string, kindVersions []string, minikubeVersions [
 	return nil
 }
 
-func sortVersions(versions []string) {
-	sort.Slice(versions, func(i, j int) bool {
-		vi := strings.Split(versions[i][1:], ".") // Remove "v" and split by "."
-		vj := strings.Split(versions[j][1:], ".")
-
-		for k := 0; k < len(vi) && k < len(vj); k++ {
-			if vi[k] != vj[k] {
-				return vi[k] > vj[k] // Sort in descending order
-			}
-		}
-		return len(vi) > len(vj)
-	})
-}
-
-func getRequest(url string) ([]byte, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch URL: %w", err)
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+func logDebug(format string, v ...interface{}) {
+	if debug {
+		log.Printf(format, v...)
 	}
+}
 
-	body, err := io.ReadAll(resp.Body)
+// matrixFilePath returns the absolute path to test-matrix.json, relative to
+// the current working directory.
+func matrixFilePath() (string, error) {
+	currentDir, err := os.Getwd()
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return "", fmt.Errorf("failed to get current directory: %w", err)
 	}
-	return body, nil
+	return filepath.Join(currentDir, filepath.Clean("tools/k8s_versions/test-matrix.json")), nil
 }
 
-func logDebug(format string, v ...interface{}) {
-	if debug {
-		log.Printf(format, v...)
+func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "upgrade-check":
+			runUpgradeCheckCommand(os.Args[2:])
+			return
+		case "from-cluster":
+			runFromClusterCommand(os.Args[2:])
+			return
+		}
 	}
+	runUpdate(os.Args[1:])
 }
 
-func main() {
+func runUpdate(args []string) {
 	// setup logging
-	flag.BoolVar(&debug, "debug", false, "Enable debug logging")
-	flag.Parse()
+	fs := flag.NewFlagSet("update", flag.ExitOnError)
+	fs.BoolVar(&debug, "debug", false, "Enable debug logging")
+	fs.Parse(args)
 	log.SetOutput(os.Stdout)
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 
@@ -247,37 +181,39 @@ func main() {
 	logDebug("Found supported k8s versions %v", k8sVersions)
 
 	var errs error
+	providerVersions := make(map[string][]string)
 
-	kindVersions, err := getLatestSupportedKindImages(k8sVersions)
-	if err != nil {
-		errs = errors.Join(errs, fmt.Errorf("failed to get kind images: %w", err))
-	}
-	if len(kindVersions) > 0 {
-		sortVersions(kindVersions)
-		logDebug("Found supported kind images: %v", kindVersions)
+	for _, provider := range providerRegistry {
+		versions, err := provider.LatestImagesFor(k8sVersions)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to get %s versions: %w", provider.Name(), err))
+			continue
+		}
+		if len(versions) > 0 {
+			SortVersionsDescending(versions)
+			logDebug("Found supported %s versions: %v", provider.Name(), versions)
+		}
+		providerVersions[provider.MatrixKey()] = versions
 	}
 
-	minikubeVersions, err := getLatestSupportedMinikubeVersions(k8sVersions)
-	if err != nil {
-		errs = errors.Join(errs, fmt.Errorf("failed to get minikube versions: %w", err))
-	}
-	if len(minikubeVersions) > 0 {
-		logDebug("Found supported minikube versions: %v", minikubeVersions)
+	anyVersions := false
+	for _, versions := range providerVersions {
+		if len(versions) > 0 {
+			anyVersions = true
+			break
+		}
 	}
-
-	if len(kindVersions) == 0 && len(minikubeVersions) == 0 || errs != nil {
+	if !anyVersions || errs != nil {
 		log.Println("No supported versions found or errors occurred: ", errs)
 		os.Exit(2)
 	}
 
-	path := "tools/k8s_versions/test-matrix.json"
-	currentDir, err := os.Getwd()
+	path, err := matrixFilePath()
 	if err != nil {
-		log.Println("Failed to get current directory: ", err)
+		log.Println(err)
 		os.Exit(1)
 	}
-	path = filepath.Join(currentDir, filepath.Clean(path))
-	err = updateMatrixFile(path, kindVersions, minikubeVersions)
+	err = updateMatrixFile(path, providerVersions)
 	if err != nil {
 		log.Println("Failed to update matrix file: ", err)
 		os.Exit(1)
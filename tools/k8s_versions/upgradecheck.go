@@ -0,0 +1,249 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+)
+
+// VersionChange describes a provider version bump for a single Kubernetes
+// cycle, e.g. kind v1.30.0 -> v1.30.4.
+type VersionChange struct {
+	Cycle string `json:"cycle"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// RemovedVersion is a provider version dropped from test-matrix.json because
+// its Kubernetes cycle is no longer supported. EOLDate is omitted when the
+// cycle's EOL date isn't known.
+type RemovedVersion struct {
+	Version string `json:"version"`
+	EOLDate string `json:"eolDate,omitempty"`
+}
+
+// ProviderDiff is the delta between a provider's current test-matrix.json
+// versions and what discovery found, grouped by Kubernetes cycle.
+type ProviderDiff struct {
+	Provider  string           `json:"provider"`
+	MatrixKey string           `json:"matrixKey"`
+	Added     []string         `json:"added,omitempty"`
+	Removed   []RemovedVersion `json:"removed,omitempty"`
+	Bumped    []VersionChange  `json:"bumped,omitempty"`
+}
+
+func (d ProviderDiff) empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Bumped) == 0
+}
+
+// diffProviderVersions compares the versions currently recorded in
+// test-matrix.json for a provider against newly discovered versions,
+// grouping both by Kubernetes cycle (MAJOR.MINOR). eolByCycle supplies the
+// EOL date to report for any cycle found only in current, i.e. one that's
+// been removed because it's no longer supported.
+func diffProviderVersions(provider Provider, current, discovered []string, eolByCycle map[string]string) ProviderDiff {
+	currentByCycle := groupByCycle(current)
+	discoveredByCycle := groupByCycle(discovered)
+
+	diff := ProviderDiff{Provider: provider.Name(), MatrixKey: provider.MatrixKey()}
+	for cycle, to := range discoveredByCycle {
+		from, ok := currentByCycle[cycle]
+		if !ok {
+			diff.Added = append(diff.Added, to)
+			continue
+		}
+		if from != to {
+			diff.Bumped = append(diff.Bumped, VersionChange{Cycle: cycle, From: from, To: to})
+		}
+	}
+	for cycle, from := range currentByCycle {
+		if _, ok := discoveredByCycle[cycle]; !ok {
+			diff.Removed = append(diff.Removed, RemovedVersion{Version: from, EOLDate: eolByCycle[cycle]})
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Slice(diff.Removed, func(i, j int) bool { return diff.Removed[i].Version < diff.Removed[j].Version })
+	sort.Slice(diff.Bumped, func(i, j int) bool { return diff.Bumped[i].Cycle > diff.Bumped[j].Cycle })
+	return diff
+}
+
+func groupByCycle(versions []string) map[string]string {
+	byCycle := make(map[string]string, len(versions))
+	for _, v := range versions {
+		byCycle[majorMinor(v)] = v
+	}
+	return byCycle
+}
+
+// readMatrixVersions loads the versions currently recorded under matrixKey
+// across every entry of test-matrix.json, deduplicated.
+func readMatrixVersions(filePath, matrixKey string) ([]string, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file: %w", err)
+	}
+
+	var testMatrix map[string]map[string][]string
+	if err := json.Unmarshal(content, &testMatrix); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var versions []string
+	for _, entry := range testMatrix {
+		for _, v := range entry[matrixKey] {
+			if !seen[v] {
+				seen[v] = true
+				versions = append(versions, v)
+			}
+		}
+	}
+	return versions, nil
+}
+
+// formatDiffText renders diffs as the human-readable report printed by
+// upgrade-check.
+func formatDiffText(diffs []ProviderDiff) string {
+	anyChanges := false
+	for _, d := range diffs {
+		if !d.empty() {
+			anyChanges = true
+			break
+		}
+	}
+	if !anyChanges {
+		return "The Kubernetes test matrix is up to date.\n"
+	}
+
+	var b strings.Builder
+	b.WriteString("The following Kubernetes test matrix updates are available:\n")
+	for _, d := range diffs {
+		for _, bump := range d.Bumped {
+			fmt.Fprintf(&b, "  %s %s -> %s\n", d.Provider, bump.From, bump.To)
+		}
+		for _, added := range d.Added {
+			fmt.Fprintf(&b, "  %s added %s\n", d.Provider, added)
+		}
+		for _, removed := range d.Removed {
+			if removed.EOLDate == "" {
+				fmt.Fprintf(&b, "  %s removed %s (no longer supported)\n", d.Provider, removed.Version)
+				continue
+			}
+			fmt.Fprintf(&b, "  %s removed %s (EOL %s)\n", d.Provider, removed.Version, removed.EOLDate)
+		}
+	}
+	return b.String()
+}
+
+// runUpgradeCheck runs discovery, diffs it against the versions currently in
+// filePath, prints a report in the requested format, optionally writes the
+// update, and reports whether any changes were found. eolByCycle supplies the
+// EOL date reported for removed cycles; see eolDatesByCycle.
+func runUpgradeCheck(filePath string, k8sVersions []KubernetesVersion, eolByCycle map[string]string, jsonOutput bool, write bool) (bool, error) {
+	var errs error
+	diffs := make([]ProviderDiff, 0, len(providerRegistry))
+	providerVersions := make(map[string][]string)
+
+	for _, provider := range providerRegistry {
+		discovered, err := provider.LatestImagesFor(k8sVersions)
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to get %s versions: %w", provider.Name(), err))
+			continue
+		}
+		SortVersionsDescending(discovered)
+		providerVersions[provider.MatrixKey()] = discovered
+
+		current, err := readMatrixVersions(filePath, provider.MatrixKey())
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to read current %s versions: %w", provider.Name(), err))
+			continue
+		}
+		diffs = append(diffs, diffProviderVersions(provider, current, discovered, eolByCycle))
+	}
+	if errs != nil {
+		return false, errs
+	}
+
+	hasChanges := false
+	for _, d := range diffs {
+		if !d.empty() {
+			hasChanges = true
+			break
+		}
+	}
+
+	if jsonOutput {
+		encoded, err := json.MarshalIndent(diffs, "", "  ")
+		if err != nil {
+			return hasChanges, fmt.Errorf("failed to marshal diff: %w", err)
+		}
+		fmt.Println(string(encoded))
+	} else {
+		fmt.Print(formatDiffText(diffs))
+	}
+
+	if write && hasChanges {
+		if err := updateMatrixFile(filePath, providerVersions); err != nil {
+			return hasChanges, fmt.Errorf("failed to update matrix file: %w", err)
+		}
+	}
+
+	return hasChanges, nil
+}
+
+// runUpgradeCheckCommand implements the "upgrade-check" subcommand: it
+// reports available Kubernetes test matrix updates without modifying
+// test-matrix.json, unless -write is set. It exits 0 when there is no diff,
+// 2 when a diff exists (mirroring `terraform plan -detailed-exitcode`), and
+// 1 on error.
+func runUpgradeCheckCommand(args []string) {
+	fs := flag.NewFlagSet("upgrade-check", flag.ExitOnError)
+	fs.BoolVar(&debug, "debug", false, "Enable debug logging")
+	output := fs.String("o", "text", `Output format: "text" or "json"`)
+	write := fs.Bool("write", false, "Update test-matrix.json if a diff is found")
+	fs.Parse(args)
+
+	log.SetOutput(os.Stdout)
+	log.SetFlags(log.LstdFlags | log.Lshortfile)
+
+	if *output != "text" && *output != "json" {
+		log.Printf(`invalid -o value %q, must be "text" or "json"`, *output)
+		os.Exit(1)
+	}
+
+	allVersions, err := getKubernetesVersions()
+	if err != nil || len(allVersions) == 0 {
+		log.Println("Failed to get k8s versions: ", err)
+		os.Exit(1)
+	}
+	k8sVersions, err := getSupportedKubernetesVersions()
+	if err != nil || len(k8sVersions) == 0 {
+		log.Println("Failed to get k8s versions: ", err)
+		os.Exit(1)
+	}
+
+	path, err := matrixFilePath()
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+
+	hasChanges, err := runUpgradeCheck(path, k8sVersions, eolDatesByCycle(allVersions), *output == "json", *write)
+	if err != nil {
+		log.Println("upgrade-check failed: ", err)
+		os.Exit(1)
+	}
+	if hasChanges {
+		os.Exit(2)
+	}
+	os.Exit(0)
+}
@@ -0,0 +1,87 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDiffProviderVersions_DetectsAddedRemovedAndBumped(t *testing.T) {
+	provider := &kindProvider{}
+	current := []string{"v1.29.0", "v1.30.0"}
+	discovered := []string{"v1.30.4", "v1.31.0"}
+
+	eolByCycle := map[string]string{"v1.29": "2024-06-28"}
+	diff := diffProviderVersions(provider, current, discovered, eolByCycle)
+
+	if len(diff.Bumped) != 1 || diff.Bumped[0].From != "v1.30.0" || diff.Bumped[0].To != "v1.30.4" {
+		t.Fatalf("Expected a bump from v1.30.0 to v1.30.4, got %v", diff.Bumped)
+	}
+	if len(diff.Added) != 1 || diff.Added[0] != "v1.31.0" {
+		t.Fatalf("Expected v1.31.0 added, got %v", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].Version != "v1.29.0" || diff.Removed[0].EOLDate != "2024-06-28" {
+		t.Fatalf("Expected v1.29.0 removed with its EOL date, got %v", diff.Removed)
+	}
+}
+
+func TestDiffProviderVersions_NoChanges_ReturnsEmptyDiff(t *testing.T) {
+	provider := &kindProvider{}
+	diff := diffProviderVersions(provider, []string{"v1.30.4"}, []string{"v1.30.4"}, nil)
+	if !diff.empty() {
+		t.Fatalf("Expected an empty diff, got %v", diff)
+	}
+}
+
+func TestFormatDiffText_NoChanges_ReportsUpToDate(t *testing.T) {
+	text := formatDiffText([]ProviderDiff{{Provider: "kind", MatrixKey: "k8s-kind-version"}})
+	if !strings.Contains(text, "up to date") {
+		t.Fatalf("Expected an up-to-date message, got %q", text)
+	}
+}
+
+func TestFormatDiffText_WithChanges_ListsThem(t *testing.T) {
+	diffs := []ProviderDiff{{
+		Provider: "kind",
+		Bumped:   []VersionChange{{Cycle: "v1.30", From: "v1.30.0", To: "v1.30.4"}},
+		Added:    []string{"v1.31.0"},
+		Removed: []RemovedVersion{
+			{Version: "v1.27.9", EOLDate: "2024-06-28"},
+			{Version: "v1.26.9"},
+		},
+	}}
+	text := formatDiffText(diffs)
+	for _, want := range []string{
+		"v1.30.0 -> v1.30.4",
+		"added v1.31.0",
+		"removed v1.27.9 (EOL 2024-06-28)",
+		"removed v1.26.9 (no longer supported)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("Expected text to contain %q, got %q", want, text)
+		}
+	}
+}
+
+func TestReadMatrixVersions_ReadsAndDedupesAcrossEntries(t *testing.T) {
+	filePath := "upgradecheck_test_matrix.json"
+	content := `{
+		"job-a": {"k8s-kind-version": ["v1.30.4", "v1.31.0"]},
+		"job-b": {"k8s-kind-version": ["v1.30.4"]}
+	}`
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	defer os.Remove(filePath)
+
+	versions, err := readMatrixVersions(filePath, "k8s-kind-version")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("Expected 2 deduplicated versions, got %v", versions)
+	}
+}
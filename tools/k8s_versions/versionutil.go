@@ -0,0 +1,70 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// NormalizeVersion canonicalizes v to "vMAJOR.MINOR.PATCH", adding a leading
+// "v" and zero-filling any missing patch component if needed, and returns an
+// error if the result still isn't a valid semantic version.
+func NormalizeVersion(v string) (string, error) {
+	canonical := semver.Canonical(ensureV(v))
+	if !semver.IsValid(canonical) {
+		return "", fmt.Errorf("not a valid version: %s", v)
+	}
+	return canonical, nil
+}
+
+// SortVersionsDescending sorts versions (with or without a leading "v") in
+// descending semantic-version order using golang.org/x/mod/semver, so that,
+// e.g., v1.10.0 correctly sorts above v1.9.0.
+func SortVersionsDescending(versions []string) {
+	sort.Slice(versions, func(i, j int) bool {
+		return semver.Compare(ensureV(versions[i]), ensureV(versions[j])) > 0
+	})
+}
+
+// LatestForCycle returns the newest tag in tags whose MAJOR.MINOR matches
+// cycle (both accepted with or without a leading "v"), or "" if none match.
+// The winning tag is returned as given (with a leading "v" added if it lacks
+// one), not semver.Max's canonicalized form, so that build metadata like
+// "+k3s1" is preserved.
+func LatestForCycle(tags []string, cycle string) string {
+	target := majorMinor(cycle)
+	best := ""
+	for _, tag := range tags {
+		v := ensureV(tag)
+		if !semver.IsValid(v) || majorMinor(v) != target {
+			continue
+		}
+		if best == "" || semver.Compare(best, v) < 0 {
+			best = v
+		}
+	}
+	return best
+}
+
+// ensureV adds a leading "v" if v doesn't already have one.
+func ensureV(v string) string {
+	if strings.HasPrefix(v, "v") {
+		return v
+	}
+	return "v" + v
+}
+
+// majorMinor returns the "vMAJOR.MINOR" prefix of v, without requiring v to
+// be a complete, valid semantic version (cycles like "1.30" have no patch).
+func majorMinor(v string) string {
+	parts := strings.SplitN(strings.TrimPrefix(ensureV(v), "v"), ".", 3)
+	if len(parts) < 2 {
+		return ensureV(v)
+	}
+	return "v" + parts[0] + "." + parts[1]
+}
@@ -0,0 +1,63 @@
+// Copyright Splunk Inc.
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import "testing"
+
+func TestSortVersionsDescending_NumericMinorOrdering(t *testing.T) {
+	versions := []string{"v1.9.0", "v1.10.0", "v1.2.0"}
+	SortVersionsDescending(versions)
+	want := []string{"v1.10.0", "v1.9.0", "v1.2.0"}
+	for i := range want {
+		if versions[i] != want[i] {
+			t.Fatalf("Expected %v, got %v", want, versions)
+		}
+	}
+}
+
+func TestSortVersionsDescending_PrereleaseSortsBelowRelease(t *testing.T) {
+	versions := []string{"v1.30.0-rc.0", "v1.30.0", "v1.30.0+k3s1"}
+	SortVersionsDescending(versions)
+	// v1.30.0 and v1.30.0+k3s1 compare equal under semver (build metadata is
+	// ignored), but both must sort above the v1.30.0-rc.0 pre-release.
+	if versions[2] != "v1.30.0-rc.0" {
+		t.Fatalf("Expected the pre-release version last, got %v", versions)
+	}
+}
+
+func TestLatestForCycle_PicksNewestPatchForCycle(t *testing.T) {
+	tags := []string{"v1.30.2", "v1.30.4", "v1.29.9", "v1.31.0"}
+	if got := LatestForCycle(tags, "1.30"); got != "v1.30.4" {
+		t.Fatalf("Expected v1.30.4, got %s", got)
+	}
+}
+
+func TestLatestForCycle_NoMatch_ReturnsEmpty(t *testing.T) {
+	if got := LatestForCycle([]string{"v1.30.4"}, "1.20"); got != "" {
+		t.Fatalf("Expected empty string, got %s", got)
+	}
+}
+
+func TestLatestForCycle_PreservesBuildMetadataOfWinningTag(t *testing.T) {
+	tags := []string{"v1.30.2+k3s1", "v1.30.4+k3s1"}
+	if got := LatestForCycle(tags, "1.30"); got != "v1.30.4+k3s1" {
+		t.Fatalf("Expected v1.30.4+k3s1 with its build metadata intact, got %s", got)
+	}
+}
+
+func TestNormalizeVersion_AddsMissingVAndZeroFillsPatch(t *testing.T) {
+	got, err := NormalizeVersion("1.30")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if got != "v1.30.0" {
+		t.Fatalf("Expected v1.30.0, got %s", got)
+	}
+}
+
+func TestNormalizeVersion_InvalidVersion_ReturnsError(t *testing.T) {
+	if _, err := NormalizeVersion("not-a-version"); err == nil {
+		t.Fatal("Expected an error, got nil")
+	}
+}